@@ -0,0 +1,219 @@
+package httperr
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Encoder serializes a Problem Details map (as produced by
+// Response.ProblemDetail) into a specific wire format.
+type Encoder interface {
+	// ContentType is the MIME type written to the Content-Type header.
+	ContentType() string
+	// Encode writes pd to w in this Encoder's format.
+	Encode(w io.Writer, pd map[string]any) error
+}
+
+// JSONEncoder encodes Problem Details as application/problem+json.
+type JSONEncoder struct{}
+
+// ContentType implements Encoder.
+func (JSONEncoder) ContentType() string { return ContentType }
+
+// Encode implements Encoder.
+func (JSONEncoder) Encode(w io.Writer, pd map[string]any) error {
+	return json.NewEncoder(w).Encode(pd)
+}
+
+// ContentTypeXML is the MIME type for the XML form of RFC 9457 Problem Details.
+const ContentTypeXML = "application/problem+xml"
+
+// standardMembers is the fixed, RFC 9457-defined member order XMLEncoder
+// emits before any extension members.
+var standardMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// XMLEncoder encodes Problem Details as application/problem+xml, under a
+// stable "problem" root element. The five standard members are emitted
+// first in RFC 9457 order; any extension members follow in sorted key
+// order, so output is deterministic.
+type XMLEncoder struct{}
+
+// ContentType implements Encoder.
+func (XMLEncoder) ContentType() string { return ContentTypeXML }
+
+// Encode implements Encoder.
+func (XMLEncoder) Encode(w io.Writer, pd map[string]any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	root := xml.StartElement{Name: xml.Name{Local: "problem"}}
+	if err := enc.EncodeToken(root); err != nil {
+		return err
+	}
+
+	for _, k := range standardMembers {
+		if v, ok := pd[k]; ok {
+			if err := enc.Encode(xmlElement{name: k, value: v}); err != nil {
+				return err
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(pd))
+	for k := range pd {
+		if _, reserved := reservedMembers[k]; reserved {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := enc.Encode(xmlElement{name: k, value: pd[k]}); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+// xmlElement recursively marshals a Problem Details value under an element
+// named name. A map[string]any has its keys visited in sorted order, and
+// the elements of a []any or []map[string]any each repeat the element,
+// keeping the output deterministic regardless of map iteration order. Any
+// other value, including other map and slice types, is written as the
+// text of fmt.Sprint.
+type xmlElement struct {
+	name  string
+	value any
+}
+
+func (el xmlElement) MarshalXML(enc *xml.Encoder, _ xml.StartElement) error {
+	switch v := el.value.(type) {
+	case map[string]any:
+		start := xml.StartElement{Name: xml.Name{Local: el.name}}
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := enc.Encode(xmlElement{name: k, value: v[k]}); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	case []map[string]any:
+		for _, item := range v {
+			if err := enc.Encode(xmlElement{name: el.name, value: item}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		for _, item := range v {
+			if err := enc.Encode(xmlElement{name: el.name, value: item}); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return enc.EncodeElement(fmt.Sprint(v), xml.StartElement{Name: xml.Name{Local: el.name}})
+	}
+}
+
+// Negotiate parses acceptHeader (RFC 9110 Accept syntax, respecting
+// q-values) and returns the encoder from encoders whose ContentType best
+// matches. It defaults to the JSON encoder in encoders (or encoders[0] if
+// none has a "problem+json" content type) when acceptHeader is empty or no
+// candidate matches.
+func Negotiate(acceptHeader string, encoders ...Encoder) Encoder {
+	if len(encoders) == 0 {
+		return JSONEncoder{}
+	}
+
+	fallback := encoders[0]
+	for _, enc := range encoders {
+		if enc.ContentType() == ContentType {
+			fallback = enc
+			break
+		}
+	}
+
+	if acceptHeader == "" {
+		return fallback
+	}
+
+	type candidate struct {
+		mediaType string
+		q         float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptHeader, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if qs, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(qs, 64); err == nil {
+				q = parsed
+			}
+		}
+		candidates = append(candidates, candidate{mediaType: mt, q: q})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if c.q <= 0 {
+			continue
+		}
+		for _, enc := range encoders {
+			if mediaTypeMatches(c.mediaType, enc.ContentType()) {
+				return enc
+			}
+		}
+	}
+
+	return fallback
+}
+
+func mediaTypeMatches(accept, contentType string) bool {
+	if accept == "*/*" {
+		return true
+	}
+	acceptType, acceptSubtype, ok := splitMediaType(accept)
+	if !ok {
+		return false
+	}
+	ctType, ctSubtype, ok := splitMediaType(contentType)
+	if !ok {
+		return false
+	}
+	if acceptType != ctType {
+		return false
+	}
+	return acceptSubtype == "*" || acceptSubtype == ctSubtype
+}
+
+func splitMediaType(mt string) (typ, subtype string, ok bool) {
+	parts := strings.SplitN(mt, "/", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
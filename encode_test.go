@@ -0,0 +1,130 @@
+package httperr_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/mickamy/httperr"
+)
+
+func samplePD() map[string]any {
+	return map[string]any{
+		"type":     "resource/not-found",
+		"title":    "Not Found",
+		"status":   http.StatusNotFound,
+		"detail":   "Resource not found",
+		"instance": "/api/v1/things/1",
+		"trace_id": "abc123",
+	}
+}
+
+func TestJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	enc := httperr.JSONEncoder{}
+
+	if enc.ContentType() != httperr.ContentType {
+		t.Errorf("ContentType() = %q, want %q", enc.ContentType(), httperr.ContentType)
+	}
+
+	var buf bytes.Buffer
+	if err := enc.Encode(&buf, samplePD()); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"trace_id":"abc123"`)) {
+		t.Errorf("Encode() output = %s, want it to contain trace_id", buf.String())
+	}
+}
+
+func TestXMLEncoder(t *testing.T) {
+	t.Parallel()
+
+	enc := httperr.XMLEncoder{}
+
+	if enc.ContentType() != httperr.ContentTypeXML {
+		t.Errorf("ContentType() = %q, want %q", enc.ContentType(), httperr.ContentTypeXML)
+	}
+
+	t.Run("stable root element and member order", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		if err := enc.Encode(&buf, samplePD()); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+
+		got := buf.String()
+		if !bytes.HasPrefix(buf.Bytes(), []byte(`<?xml`)) {
+			t.Errorf("Encode() output missing XML header: %s", got)
+		}
+		wantOrder := []string{"<problem>", "<type>", "<title>", "<status>", "<detail>", "<instance>", "<trace_id>"}
+		last := 0
+		for _, tag := range wantOrder {
+			idx := bytes.Index(buf.Bytes()[last:], []byte(tag))
+			if idx < 0 {
+				t.Fatalf("Encode() output = %s, missing or out-of-order tag %q", got, tag)
+			}
+			last += idx + len(tag)
+		}
+	})
+
+	t.Run("deterministic ordering across repeated encodes", func(t *testing.T) {
+		t.Parallel()
+
+		pd := map[string]any{
+			"type":   "about:blank",
+			"title":  "Internal Server Error",
+			"status": http.StatusInternalServerError,
+			"zeta":   "1",
+			"alpha":  "2",
+		}
+
+		var first bytes.Buffer
+		if err := enc.Encode(&first, pd); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		for i := 0; i < 5; i++ {
+			var buf bytes.Buffer
+			if err := enc.Encode(&buf, pd); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if buf.String() != first.String() {
+				t.Fatalf("Encode() output is not deterministic:\n%s\nvs\n%s", first.String(), buf.String())
+			}
+		}
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	json := httperr.JSONEncoder{}
+	xmlEnc := httperr.XMLEncoder{}
+
+	tests := []struct {
+		name   string
+		accept string
+		want   httperr.Encoder
+	}{
+		{"empty defaults to json", "", json},
+		{"exact json", "application/problem+json", json},
+		{"exact xml", "application/problem+xml", xmlEnc},
+		{"wildcard subtype", "application/*", json},
+		{"wildcard any", "*/*", json},
+		{"q-values prefer higher", "application/problem+json;q=0.2, application/problem+xml;q=0.8", xmlEnc},
+		{"unmatched type falls back to json", "text/plain", json},
+		{"q=0 excludes candidate", "application/problem+xml;q=0, application/problem+json;q=0.5", json},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := httperr.Negotiate(tt.accept, json, xmlEnc)
+			if got.ContentType() != tt.want.ContentType() {
+				t.Errorf("Negotiate(%q) = %q, want %q", tt.accept, got.ContentType(), tt.want.ContentType())
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package httperr
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"golang.org/x/text/language"
@@ -50,14 +52,22 @@ var defaultConfig = Config{
 
 // Response is the resolved response information.
 type Response struct {
-	Type   string // RFC 9457 type
-	Title  string // Short description
-	Status int    // HTTP status code
-	Detail string // Localized message (from Localizable)
+	Type       string         // RFC 9457 type
+	Title      string         // Short description
+	Status     int            // HTTP status code
+	Detail     string         // Localized message (from Localizable)
+	Extensions map[string]any // Extension members (from ExtensionProvider)
+}
+
+// reservedMembers are the standard RFC 9457 members; extension members
+// using these names are dropped rather than overriding them.
+var reservedMembers = map[string]struct{}{
+	"type": {}, "title": {}, "status": {}, "detail": {}, "instance": {},
 }
 
 // ProblemDetail returns RFC 9457 compliant map.
 // If baseURI is provided and Type is not an absolute URI, Type is resolved against baseURI.
+// Extensions are merged in last, skipping any key that collides with a reserved member name.
 func (r *Response) ProblemDetail(instance string, baseURI ...string) map[string]any {
 	typeURI := r.Type
 	if len(baseURI) > 0 && baseURI[0] != "" && !isAbsoluteURI(r.Type) {
@@ -75,20 +85,45 @@ func (r *Response) ProblemDetail(instance string, baseURI ...string) map[string]
 	if instance != "" {
 		pd["instance"] = instance
 	}
+	for k, v := range r.Extensions {
+		if _, reserved := reservedMembers[k]; reserved {
+			continue
+		}
+		pd[k] = v
+	}
 	return pd
 }
 
+// isAbsoluteURI reports whether uri is an absolute URI per RFC 3986, i.e.
+// it has a scheme (http, https, about, urn, tag, ...), not just an
+// http(s)/about prefix.
 func isAbsoluteURI(uri string) bool {
-	return strings.HasPrefix(uri, "http://") ||
-		strings.HasPrefix(uri, "https://") ||
-		strings.HasPrefix(uri, "about:")
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return u.IsAbs()
 }
 
+// resolveURI resolves ref against base per RFC 3986 §5. base is treated as
+// a directory (a trailing "/" is added if missing) so that a relative ref
+// is appended to base rather than replacing its last path segment.
 func resolveURI(base, ref string) string {
-	if !strings.HasSuffix(base, "/") {
-		base += "/"
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return base + ref
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return base + ref
+	}
+
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		baseURL.Path += "/"
+		baseURL.RawPath = ""
 	}
-	return base + ref
+
+	return baseURL.ResolveReference(refURL).String()
 }
 
 // Localizable is an error that can be localized.
@@ -96,9 +131,83 @@ type Localizable interface {
 	Localize(tag language.Tag) string
 }
 
+// Translatable is an error that carries a message catalog ID, for use with
+// a message bundle (see httperr/i18n) instead of hand-wired translations.
+type Translatable interface {
+	TranslateID() string
+}
+
+// TranslatableData is an optional extension of Translatable that supplies
+// template data for message interpolation.
+type TranslatableData interface {
+	Translatable
+	TranslateData() map[string]any
+}
+
+// TranslatablePluralCount is an optional extension of Translatable that
+// supplies the plural count used to select a plural form.
+type TranslatablePluralCount interface {
+	Translatable
+	TranslatePluralCount() any
+}
+
+// ExtensionProvider is an error that contributes RFC 9457 extension members,
+// merged into ProblemDetail's output by Resolve.
+type ExtensionProvider interface {
+	ProblemExtensions() map[string]any
+}
+
+// LocalizedExtensionProvider is an optional alternative to ExtensionProvider
+// for errors whose extension members are themselves localized, such as
+// ValidationError's per-field messages.
+type LocalizedExtensionProvider interface {
+	ProblemExtensionsForTag(tag language.Tag) map[string]any
+}
+
+// FieldError describes a single field-level validation failure.
+type FieldError struct {
+	Field    string
+	Code     string
+	Messages map[language.Tag]string // localized messages, keyed by tag
+}
+
+// Localize returns the message for tag, falling back to English.
+func (e FieldError) Localize(tag language.Tag) string {
+	if msg, ok := e.Messages[tag]; ok {
+		return msg
+	}
+	return e.Messages[language.English]
+}
+
+// ValidationError wraps one or more field-level validation failures. It
+// implements LocalizedExtensionProvider, so Resolve surfaces the fields as
+// an "errors" extension member, with each message localized to the
+// resolved tag.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation failed for %d field(s)", len(e.Fields))
+}
+
+// ProblemExtensionsForTag implements LocalizedExtensionProvider.
+func (e *ValidationError) ProblemExtensionsForTag(tag language.Tag) map[string]any {
+	errs := make([]map[string]any, len(e.Fields))
+	for i, f := range e.Fields {
+		errs[i] = map[string]any{
+			"field":   f.Field,
+			"code":    f.Code,
+			"message": f.Localize(tag),
+		}
+	}
+	return map[string]any{"errors": errs}
+}
+
 // Resolve resolves an error to response information.
 //   - Finds Config from Map using errors.Is
 //   - Finds Localizable using errors.As and localizes Detail
+//   - Finds LocalizedExtensionProvider, then ExtensionProvider, using errors.As and fills Extensions
 func Resolve(err error, m Map, tag language.Tag) Response {
 	config := m.Match(err)
 
@@ -108,10 +217,23 @@ func Resolve(err error, m Map, tag language.Tag) Response {
 		detail = loc.Localize(tag)
 	}
 
+	var extensions map[string]any
+	var lep LocalizedExtensionProvider
+	switch {
+	case errors.As(err, &lep):
+		extensions = lep.ProblemExtensionsForTag(tag)
+	default:
+		var ep ExtensionProvider
+		if errors.As(err, &ep) {
+			extensions = ep.ProblemExtensions()
+		}
+	}
+
 	return Response{
-		Type:   config.Type,
-		Title:  config.Title,
-		Status: config.Status,
-		Detail: detail,
+		Type:       config.Type,
+		Title:      config.Title,
+		Status:     config.Status,
+		Detail:     detail,
+		Extensions: extensions,
 	}
 }
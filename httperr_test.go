@@ -207,6 +207,22 @@ func TestResponse_ProblemDetail(t *testing.T) {
 			t.Errorf("type = %v, want %v", pd["type"], "about:blank")
 		}
 	})
+
+	t.Run("urn type ignores baseURI", func(t *testing.T) {
+		t.Parallel()
+
+		resp := httperr.Response{
+			Type:   "urn:example:foo",
+			Title:  "Foo",
+			Status: http.StatusBadRequest,
+		}
+
+		pd := resp.ProblemDetail("", "https://api.example.com/problems/")
+
+		if pd["type"] != "urn:example:foo" {
+			t.Errorf("type = %v, want %v", pd["type"], "urn:example:foo")
+		}
+	})
 }
 
 func TestResolve(t *testing.T) {
@@ -288,4 +304,69 @@ func TestResolve(t *testing.T) {
 			t.Errorf("Detail = %q, want %q", resp.Detail, "Please log in")
 		}
 	})
+
+	t.Run("validation error with localized field messages", func(t *testing.T) {
+		t.Parallel()
+
+		err := &httperr.ValidationError{
+			Fields: []httperr.FieldError{
+				{
+					Field: "email",
+					Code:  "required",
+					Messages: map[language.Tag]string{
+						language.English:  "email is required",
+						language.Japanese: "メールアドレスは必須です",
+					},
+				},
+			},
+		}
+
+		resp := httperr.Resolve(err, errMap, language.Japanese)
+
+		fields, ok := resp.Extensions["errors"].([]map[string]any)
+		if !ok || len(fields) != 1 {
+			t.Fatalf("Extensions[%q] = %#v, want a single-element []map[string]any", "errors", resp.Extensions["errors"])
+		}
+		if fields[0]["message"] != "メールアドレスは必須です" {
+			t.Errorf("message = %v, want %v", fields[0]["message"], "メールアドレスは必須です")
+		}
+	})
+}
+
+func TestResponse_ProblemDetail_Extensions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("extensions are merged in", func(t *testing.T) {
+		t.Parallel()
+
+		resp := httperr.Response{
+			Type:       "validation/failed",
+			Title:      "Validation Failed",
+			Status:     http.StatusUnprocessableEntity,
+			Extensions: map[string]any{"trace_id": "abc123"},
+		}
+
+		pd := resp.ProblemDetail("")
+
+		if pd["trace_id"] != "abc123" {
+			t.Errorf("trace_id = %v, want %v", pd["trace_id"], "abc123")
+		}
+	})
+
+	t.Run("extensions cannot override reserved members", func(t *testing.T) {
+		t.Parallel()
+
+		resp := httperr.Response{
+			Type:       "validation/failed",
+			Title:      "Validation Failed",
+			Status:     http.StatusUnprocessableEntity,
+			Extensions: map[string]any{"status": 1},
+		}
+
+		pd := resp.ProblemDetail("")
+
+		if pd["status"] != http.StatusUnprocessableEntity {
+			t.Errorf("status = %v, want %v", pd["status"], http.StatusUnprocessableEntity)
+		}
+	})
 }
@@ -0,0 +1,17 @@
+// Package chi re-exports httpmw.Middleware for discoverability when wiring
+// a go-chi/chi router. chi's Router.Use already accepts the standard
+// func(http.Handler) http.Handler signature httpmw.Middleware returns.
+package chi
+
+import (
+	"net/http"
+
+	"github.com/mickamy/httperr"
+	"github.com/mickamy/httperr/httpmw"
+)
+
+// Middleware is httpmw.Middleware, exposed under this import path so that
+// r.Use(chi.Middleware(m)) reads consistently alongside the gin adapter.
+func Middleware(m httperr.Map, opts ...httpmw.Option) func(http.Handler) http.Handler {
+	return httpmw.Middleware(m, opts...)
+}
@@ -0,0 +1,31 @@
+// Package gin adapts httpmw.Middleware for gin-gonic/gin.
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/mickamy/httperr"
+	"github.com/mickamy/httperr/httpmw"
+)
+
+// Middleware returns a gin.HandlerFunc that binds m and opts to the request
+// via httpmw.Bind, recovers panics raised by downstream handlers, and
+// writes any error attached to the gin.Context through c.Error.
+func Middleware(m httperr.Map, opts ...httpmw.Option) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = httpmw.Bind(c.Request, m, opts...)
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				httpmw.Write(c.Writer, c.Request, httpmw.Recover(rec))
+				c.Abort()
+			}
+		}()
+
+		c.Next()
+
+		if len(c.Errors) > 0 && !c.Writer.Written() {
+			httpmw.Write(c.Writer, c.Request, c.Errors.Last().Err)
+		}
+	}
+}
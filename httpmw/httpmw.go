@@ -0,0 +1,169 @@
+// Package httpmw wires httperr into net/http based servers: it recovers
+// panics, resolves the caller's preferred language from Accept-Language,
+// and writes RFC 9457 Problem Details responses.
+package httpmw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/text/language"
+
+	"github.com/mickamy/httperr"
+)
+
+// Option configures Middleware.
+type Option func(*config)
+
+type config struct {
+	baseURI   string
+	instance  func(r *http.Request) string
+	logger    func(r *http.Request, err error)
+	extension func(r *http.Request, err error) map[string]any
+	supported []language.Tag
+	encoders  []httperr.Encoder
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		instance:  func(r *http.Request) string { return r.URL.Path },
+		supported: []language.Tag{language.English},
+		encoders:  []httperr.Encoder{httperr.JSONEncoder{}, httperr.XMLEncoder{}},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithBaseURI sets the base URI used to resolve relative Config.Type values.
+// See httperr.Response.ProblemDetail.
+func WithBaseURI(uri string) Option {
+	return func(c *config) { c.baseURI = uri }
+}
+
+// WithInstance overrides how the "instance" member is derived from the
+// request. It defaults to r.URL.Path.
+func WithInstance(f func(r *http.Request) string) Option {
+	return func(c *config) { c.instance = f }
+}
+
+// WithLogger registers a hook invoked for every response with a 5xx status.
+func WithLogger(f func(r *http.Request, err error)) Option {
+	return func(c *config) { c.logger = f }
+}
+
+// WithExtensionMembers registers a hook that returns extension members to
+// merge into the Problem Details output. Keys colliding with a reserved
+// member name ("type", "title", "status", "detail", "instance") are dropped.
+func WithExtensionMembers(f func(r *http.Request, err error) map[string]any) Option {
+	return func(c *config) { c.extension = f }
+}
+
+// WithSupportedLanguages sets the tags considered when matching
+// Accept-Language. It defaults to []language.Tag{language.English}.
+func WithSupportedLanguages(tags ...language.Tag) Option {
+	return func(c *config) { c.supported = tags }
+}
+
+// WithEncoders sets the candidate Encoders used to content-negotiate the
+// response body against the request's Accept header (see httperr.Negotiate).
+// It defaults to []httperr.Encoder{httperr.JSONEncoder{}, httperr.XMLEncoder{}}.
+func WithEncoders(encoders ...httperr.Encoder) Option {
+	return func(c *config) { c.encoders = encoders }
+}
+
+type ctxKey struct{}
+
+type binding struct {
+	m httperr.Map
+	c *config
+}
+
+// Bind attaches m and opts to r's context so that Write can later resolve
+// against them. Middleware calls this for plain net/http handlers; adapters
+// that manage their own request lifecycle (see httpmw/gin) call it directly
+// instead.
+func Bind(r *http.Request, m httperr.Map, opts ...Option) *http.Request {
+	c := newConfig(opts)
+	return r.WithContext(context.WithValue(r.Context(), ctxKey{}, &binding{m: m, c: c}))
+}
+
+// Recover converts a value recovered from a panic into an error suitable
+// for Write, wrapping it with fmt.Errorf when it is not already one.
+func Recover(rec any) error {
+	if err, ok := rec.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", rec)
+}
+
+// Middleware recovers panics raised downstream, resolves them against m,
+// and writes an RFC 9457 Problem Details response. It also binds m and the
+// given Options to the request so that Write can be called from handlers.
+func Middleware(m httperr.Map, opts ...Option) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = Bind(r, m, opts...)
+
+			defer func() {
+				if rec := recover(); rec != nil {
+					Write(w, r, Recover(rec))
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Write resolves err using the Map and Options bound to r by Bind or
+// Middleware and writes an RFC 9457 Problem Details response to w. It
+// panics if r was not served through Middleware (or Bind).
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	b, ok := r.Context().Value(ctxKey{}).(*binding)
+	if !ok {
+		panic("httpmw: Write called on a request not served through Middleware")
+	}
+	write(w, r, err, b.m, b.c)
+}
+
+var reservedMembers = map[string]struct{}{
+	"type": {}, "title": {}, "status": {}, "detail": {}, "instance": {},
+}
+
+func write(w http.ResponseWriter, r *http.Request, err error, m httperr.Map, c *config) {
+	tag := matchLanguage(r, c.supported)
+	resp := httperr.Resolve(err, m, tag)
+
+	if resp.Status >= http.StatusInternalServerError && c.logger != nil {
+		c.logger(r, err)
+	}
+
+	var baseURI []string
+	if c.baseURI != "" {
+		baseURI = []string{c.baseURI}
+	}
+	pd := resp.ProblemDetail(c.instance(r), baseURI...)
+
+	if c.extension != nil {
+		for k, v := range c.extension(r, err) {
+			if _, reserved := reservedMembers[k]; reserved {
+				continue
+			}
+			pd[k] = v
+		}
+	}
+
+	enc := httperr.Negotiate(r.Header.Get("Accept"), c.encoders...)
+	w.Header().Set("Content-Type", enc.ContentType())
+	w.WriteHeader(resp.Status)
+	_ = enc.Encode(w, pd)
+}
+
+func matchLanguage(r *http.Request, supported []language.Tag) language.Tag {
+	matcher := language.NewMatcher(supported)
+	tag, _ := language.MatchStrings(matcher, r.Header.Get("Accept-Language"))
+	return tag
+}
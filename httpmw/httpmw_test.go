@@ -0,0 +1,154 @@
+package httpmw_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mickamy/httperr"
+	"github.com/mickamy/httperr/httpmw"
+)
+
+var ErrNotFound = errors.New("not found")
+
+func newMap() httperr.Map {
+	return httperr.Map{
+		ErrNotFound: httperr.New("resource/not-found", "Not Found", http.StatusNotFound),
+	}
+}
+
+func TestMiddleware_RecoversPanic(t *testing.T) {
+	t.Parallel()
+
+	handler := httpmw.Middleware(newMap())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(ErrNotFound)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/things/1", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != httperr.ContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, httperr.ContentType)
+	}
+
+	var pd map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &pd); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if pd["instance"] != "/things/1" {
+		t.Errorf("instance = %v, want %v", pd["instance"], "/things/1")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	handler := httpmw.Middleware(newMap())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpmw.Write(w, r, ErrNotFound)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/things/2", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestWrite_WithoutMiddleware_Panics(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Write() did not panic for an unbound request")
+		}
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, "/things/3", nil)
+	httpmw.Write(httptest.NewRecorder(), req, ErrNotFound)
+}
+
+func TestMiddleware_ExtensionMembers(t *testing.T) {
+	t.Parallel()
+
+	handler := httpmw.Middleware(
+		newMap(),
+		httpmw.WithExtensionMembers(func(r *http.Request, err error) map[string]any {
+			return map[string]any{
+				"trace_id": "abc123",
+				"status":   999, // reserved, must be dropped
+			}
+		}),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpmw.Write(w, r, ErrNotFound)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/things/4", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	var pd map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &pd); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if pd["trace_id"] != "abc123" {
+		t.Errorf("trace_id = %v, want %v", pd["trace_id"], "abc123")
+	}
+	if pd["status"] != float64(http.StatusNotFound) {
+		t.Errorf("status = %v, want %v (reserved key must not be overridden)", pd["status"], http.StatusNotFound)
+	}
+}
+
+func TestMiddleware_ContentNegotiation(t *testing.T) {
+	t.Parallel()
+
+	handler := httpmw.Middleware(newMap())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpmw.Write(w, r, ErrNotFound)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/things/6", nil)
+	req.Header.Set("Accept", "application/problem+xml")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != httperr.ContentTypeXML {
+		t.Errorf("Content-Type = %q, want %q", ct, httperr.ContentTypeXML)
+	}
+}
+
+func TestMiddleware_Logger(t *testing.T) {
+	t.Parallel()
+
+	var logged error
+	handler := httpmw.Middleware(
+		httperr.Map{},
+		httpmw.WithLogger(func(r *http.Request, err error) {
+			logged = err
+		}),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpmw.Write(w, r, errors.New("boom"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/things/5", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if logged == nil {
+		t.Error("logger hook was not invoked for a 5xx response")
+	}
+}
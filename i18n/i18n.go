@@ -0,0 +1,92 @@
+// Package i18n adds go-i18n backed message catalogs to httperr, so errors
+// can carry a message ID (via httperr.Translatable) instead of each error
+// type embedding its own translation map.
+package i18n
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+
+	"github.com/mickamy/httperr"
+)
+
+// Bundle is a message catalog that Resolve uses to localize errors
+// implementing httperr.Translatable.
+type Bundle struct {
+	inner *i18n.Bundle
+}
+
+// NewBundle creates a Bundle whose messages are assumed to be written in
+// defaultLanguage when no translation matches the requested tag.
+func NewBundle(defaultLanguage language.Tag) *Bundle {
+	b := i18n.NewBundle(defaultLanguage)
+	b.RegisterUnmarshalFunc("toml", toml.Unmarshal)
+	return &Bundle{inner: b}
+}
+
+// LoadFS walks fsys under root, parsing every *.toml message file it finds
+// and registering it with the bundle.
+func (b *Bundle) LoadFS(fsys fs.FS, root string) error {
+	return fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".toml" {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		_, err = b.inner.ParseMessageFileBytes(data, path)
+		return err
+	})
+}
+
+// Localize resolves messageID for tag, substituting templateData and
+// selecting a plural form from pluralCount when the message defines one.
+func (b *Bundle) Localize(tag language.Tag, messageID string, templateData map[string]any, pluralCount any) (string, error) {
+	localizer := i18n.NewLocalizer(b.inner, tag.String())
+	return localizer.Localize(&i18n.LocalizeConfig{
+		MessageID:    messageID,
+		TemplateData: templateData,
+		PluralCount:  pluralCount,
+	})
+}
+
+// Resolve resolves err like httperr.Resolve, but first tries to localize
+// the Detail through bundle when err implements httperr.Translatable,
+// falling back to httperr.Localizable and then to an empty string.
+func Resolve(err error, m httperr.Map, tag language.Tag, bundle *Bundle) httperr.Response {
+	resp := httperr.Resolve(err, m, tag)
+
+	if bundle == nil {
+		return resp
+	}
+
+	var t httperr.Translatable
+	if !errors.As(err, &t) {
+		return resp
+	}
+
+	var data map[string]any
+	if td, ok := t.(httperr.TranslatableData); ok {
+		data = td.TranslateData()
+	}
+
+	var pluralCount any
+	if tp, ok := t.(httperr.TranslatablePluralCount); ok {
+		pluralCount = tp.TranslatePluralCount()
+	}
+
+	if msg, lerr := bundle.Localize(tag, t.TranslateID(), data, pluralCount); lerr == nil {
+		resp.Detail = msg
+	}
+
+	return resp
+}
@@ -0,0 +1,163 @@
+package i18n_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/language"
+
+	"github.com/mickamy/httperr"
+	"github.com/mickamy/httperr/i18n"
+)
+
+var ErrQuotaExceeded = httperr.New("quota/exceeded", "Quota Exceeded", 429)
+
+// translatableError implements httperr.Translatable.
+type translatableError struct {
+	id     string
+	data   map[string]any
+	plural any
+}
+
+func (e *translatableError) Error() string              { return e.id }
+func (e *translatableError) TranslateID() string         { return e.id }
+func (e *translatableError) TranslateData() map[string]any { return e.data }
+func (e *translatableError) TranslatePluralCount() any   { return e.plural }
+
+func newBundle(t *testing.T) *i18n.Bundle {
+	t.Helper()
+
+	fsys := fstest.MapFS{
+		"active.en.toml": &fstest.MapFile{Data: []byte(`
+Greeting = "Hello, {{.Name}}!"
+
+[UnreadEmails]
+one = "You have {{.PluralCount}} unread email."
+other = "You have {{.PluralCount}} unread emails."
+`)},
+		"active.ja.toml": &fstest.MapFile{Data: []byte(`
+Greeting = "こんにちは、{{.Name}}さん!"
+`)},
+	}
+
+	b := i18n.NewBundle(language.English)
+	if err := b.LoadFS(fsys, "."); err != nil {
+		t.Fatalf("LoadFS() error = %v", err)
+	}
+	return b
+}
+
+func TestBundle_Localize(t *testing.T) {
+	t.Parallel()
+
+	b := newBundle(t)
+
+	t.Run("template data", func(t *testing.T) {
+		t.Parallel()
+
+		msg, err := b.Localize(language.English, "Greeting", map[string]any{"Name": "Alice"}, nil)
+		if err != nil {
+			t.Fatalf("Localize() error = %v", err)
+		}
+		if want := "Hello, Alice!"; msg != want {
+			t.Errorf("Localize() = %q, want %q", msg, want)
+		}
+	})
+
+	t.Run("uses translation for requested language", func(t *testing.T) {
+		t.Parallel()
+
+		msg, err := b.Localize(language.Japanese, "Greeting", map[string]any{"Name": "太郎"}, nil)
+		if err != nil {
+			t.Fatalf("Localize() error = %v", err)
+		}
+		if want := "こんにちは、太郎さん!"; msg != want {
+			t.Errorf("Localize() = %q, want %q", msg, want)
+		}
+	})
+
+	t.Run("plural form", func(t *testing.T) {
+		t.Parallel()
+
+		one, err := b.Localize(language.English, "UnreadEmails", nil, 1)
+		if err != nil {
+			t.Fatalf("Localize() error = %v", err)
+		}
+		if want := "You have 1 unread email."; one != want {
+			t.Errorf("Localize() = %q, want %q", one, want)
+		}
+
+		other, err := b.Localize(language.English, "UnreadEmails", nil, 3)
+		if err != nil {
+			t.Fatalf("Localize() error = %v", err)
+		}
+		if want := "You have 3 unread emails."; other != want {
+			t.Errorf("Localize() = %q, want %q", other, want)
+		}
+	})
+
+	t.Run("missing translation", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := b.Localize(language.English, "DoesNotExist", nil, nil); err == nil {
+			t.Error("Localize() error = nil, want error for missing message")
+		}
+	})
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	b := newBundle(t)
+	errMap := httperr.Map{
+		ErrQuotaExceeded: httperr.New("quota/exceeded", "Quota Exceeded", 429),
+	}
+
+	t.Run("translatable error resolves detail from bundle", func(t *testing.T) {
+		t.Parallel()
+
+		err := &translatableError{id: "Greeting", data: map[string]any{"Name": "Bob"}}
+
+		resp := i18n.Resolve(err, errMap, language.English, b)
+
+		if want := "Hello, Bob!"; resp.Detail != want {
+			t.Errorf("Detail = %q, want %q", resp.Detail, want)
+		}
+	})
+
+	t.Run("plural count is forwarded", func(t *testing.T) {
+		t.Parallel()
+
+		err := &translatableError{id: "UnreadEmails", plural: 5}
+
+		resp := i18n.Resolve(err, errMap, language.English, b)
+
+		if want := "You have 5 unread emails."; resp.Detail != want {
+			t.Errorf("Detail = %q, want %q", resp.Detail, want)
+		}
+	})
+
+	t.Run("missing translation falls back to empty string", func(t *testing.T) {
+		t.Parallel()
+
+		err := &translatableError{id: "DoesNotExist"}
+
+		resp := i18n.Resolve(err, errMap, language.English, b)
+
+		if resp.Detail != "" {
+			t.Errorf("Detail = %q, want empty", resp.Detail)
+		}
+	})
+
+	t.Run("nil bundle leaves detail empty for non-Localizable error", func(t *testing.T) {
+		t.Parallel()
+
+		err := &translatableError{id: "Greeting"}
+
+		resp := i18n.Resolve(err, errMap, language.English, nil)
+
+		if resp.Detail != "" {
+			t.Errorf("Detail = %q, want empty", resp.Detail)
+		}
+	})
+}
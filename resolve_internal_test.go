@@ -0,0 +1,84 @@
+package httperr
+
+import "testing"
+
+func TestIsAbsoluteURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		uri  string
+		want bool
+	}{
+		{"http", "http://example.com/problems/x", true},
+		{"https", "https://example.com/problems/x", true},
+		{"about", "about:blank", true},
+		{"urn", "urn:example:foo", true},
+		{"tag", "tag:example.com,2024:foo", true},
+		{"relative", "resource/not-found", false},
+		{"relative with leading slash", "/resource/not-found", false},
+		{"relative with query", "resource?x=1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isAbsoluteURI(tt.uri); got != tt.want {
+				t.Errorf("isAbsoluteURI(%q) = %v, want %v", tt.uri, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		base string
+		ref  string
+		want string
+	}{
+		{
+			name: "base without trailing slash does not lose a segment",
+			base: "https://api.example.com/v2/problems",
+			ref:  "auth/x",
+			want: "https://api.example.com/v2/problems/auth/x",
+		},
+		{
+			name: "base with trailing slash",
+			base: "https://api.example.com/problems/",
+			ref:  "not-found",
+			want: "https://api.example.com/problems/not-found",
+		},
+		{
+			name: "ref with query and fragment",
+			base: "https://api.example.com/problems/",
+			ref:  "not-found?reason=missing#details",
+			want: "https://api.example.com/problems/not-found?reason=missing#details",
+		},
+		{
+			name: "ref with dot-dot segments is normalized",
+			base: "https://api.example.com/v2/problems/",
+			ref:  "../v1/not-found",
+			want: "https://api.example.com/v2/v1/not-found",
+		},
+		{
+			name: "percent-encoded ref is preserved",
+			base: "https://api.example.com/problems/",
+			ref:  "not%2Ffound",
+			want: "https://api.example.com/problems/not%2Ffound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := resolveURI(tt.base, tt.ref); got != tt.want {
+				t.Errorf("resolveURI(%q, %q) = %q, want %q", tt.base, tt.ref, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Package status bridges httperr.Config to gRPC and Connect status codes,
+// so the same httperr.Map can drive both an HTTP (RFC 9457) and a
+// gRPC/Connect transport.
+package status
+
+import (
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/mickamy/httperr"
+)
+
+// Table maps HTTP status codes to gRPC codes. ToGRPCError, ToConnectError,
+// and their reverse mappings all go through a Table. A Table passed via
+// WithTable replaces DefaultTable as a whole; entries it lacks are not
+// taken from DefaultTable.
+type Table map[int]codes.Code
+
+// DefaultTable is the default HTTP-status -> gRPC-code mapping.
+var DefaultTable = Table{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// GRPCCode maps an HTTP status to a gRPC code using t, falling back to
+// codes.Internal for 5xx and codes.Unknown otherwise.
+func (t Table) GRPCCode(httpStatus int) codes.Code {
+	if c, ok := t[httpStatus]; ok {
+		return c
+	}
+	if httpStatus >= http.StatusInternalServerError {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// ConnectCode maps an HTTP status to a connect.Code. connect.Code shares
+// the gRPC code space, so this is GRPCCode reinterpreted as a connect.Code.
+func (t Table) ConnectCode(httpStatus int) connect.Code {
+	return connect.Code(t.GRPCCode(httpStatus))
+}
+
+// HTTPStatus reverse-maps a gRPC code to an HTTP status using t, falling
+// back to 500 Internal Server Error when code has no entry in t.
+func (t Table) HTTPStatus(code codes.Code) int {
+	for httpStatus, c := range t {
+		if c == code {
+			return httpStatus
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// Option configures ToGRPCError and ToConnectError.
+type Option func(*config)
+
+type config struct {
+	table Table
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{table: DefaultTable}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithTable overrides the default HTTP-status -> gRPC-code mapping.
+func WithTable(t Table) Option {
+	return func(c *config) { c.table = t }
+}
+
+// ToGRPCError resolves err against m and tag and returns a gRPC status
+// error whose code comes from the Table and whose message is the
+// localized detail (falling back to the Config's title). The Config's
+// type URI, when set, is attached as a status detail.
+func ToGRPCError(err error, m httperr.Map, tag language.Tag, opts ...Option) error {
+	c := newConfig(opts)
+	resp := httperr.Resolve(err, m, tag)
+
+	msg := resp.Detail
+	if msg == "" {
+		msg = resp.Title
+	}
+
+	st := grpcstatus.New(c.table.GRPCCode(resp.Status), msg)
+	if resp.Type != "" {
+		if withType, derr := st.WithDetails(wrapperspb.String(resp.Type)); derr == nil {
+			st = withType
+		}
+	}
+	return st.Err()
+}
+
+// ToConnectError resolves err against m and tag and returns a connect.Error
+// whose code comes from the Table and whose message is the localized
+// detail (falling back to the Config's title). The Config's type URI,
+// when set, is attached as a status detail.
+func ToConnectError(err error, m httperr.Map, tag language.Tag, opts ...Option) error {
+	c := newConfig(opts)
+	resp := httperr.Resolve(err, m, tag)
+
+	msg := resp.Detail
+	if msg == "" {
+		msg = resp.Title
+	}
+
+	cerr := connect.NewError(c.table.ConnectCode(resp.Status), errors.New(msg))
+	if resp.Type != "" {
+		if detail, derr := connect.NewErrorDetail(wrapperspb.String(resp.Type)); derr == nil {
+			cerr.AddDetail(detail)
+		}
+	}
+	return cerr
+}
@@ -0,0 +1,97 @@
+package status_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"golang.org/x/text/language"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/mickamy/httperr"
+	"github.com/mickamy/httperr/status"
+)
+
+var ErrNotFound = errors.New("not found")
+
+func newMap() httperr.Map {
+	return httperr.Map{
+		ErrNotFound: httperr.New("resource/not-found", "Not Found", http.StatusNotFound),
+	}
+}
+
+func TestTable_GRPCCode(t *testing.T) {
+	t.Parallel()
+
+	if got := status.DefaultTable.GRPCCode(http.StatusNotFound); got != grpccodes.NotFound {
+		t.Errorf("GRPCCode(%d) = %v, want %v", http.StatusNotFound, got, grpccodes.NotFound)
+	}
+	if got := status.DefaultTable.GRPCCode(599); got != grpccodes.Internal {
+		t.Errorf("GRPCCode(599) = %v, want %v (unmapped 5xx falls back to Internal)", got, grpccodes.Internal)
+	}
+	if got := status.DefaultTable.GRPCCode(499); got != grpccodes.Unknown {
+		t.Errorf("GRPCCode(499) = %v, want %v (unmapped 4xx falls back to Unknown)", got, grpccodes.Unknown)
+	}
+}
+
+func TestTable_HTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	if got := status.DefaultTable.HTTPStatus(grpccodes.NotFound); got != http.StatusNotFound {
+		t.Errorf("HTTPStatus(%v) = %d, want %d", grpccodes.NotFound, got, http.StatusNotFound)
+	}
+	if got := status.DefaultTable.HTTPStatus(grpccodes.Code(999)); got != http.StatusInternalServerError {
+		t.Errorf("HTTPStatus(999) = %d, want %d", got, http.StatusInternalServerError)
+	}
+}
+
+func TestToGRPCError(t *testing.T) {
+	t.Parallel()
+
+	err := status.ToGRPCError(ErrNotFound, newMap(), language.English)
+
+	st, ok := grpcstatus.FromError(err)
+	if !ok {
+		t.Fatalf("FromError(%v) ok = false", err)
+	}
+	if st.Code() != grpccodes.NotFound {
+		t.Errorf("Code() = %v, want %v", st.Code(), grpccodes.NotFound)
+	}
+	if st.Message() != "Not Found" {
+		t.Errorf("Message() = %q, want %q", st.Message(), "Not Found")
+	}
+}
+
+func TestToGRPCError_WithTable(t *testing.T) {
+	t.Parallel()
+
+	custom := status.Table{http.StatusNotFound: grpccodes.Unavailable}
+	err := status.ToGRPCError(ErrNotFound, newMap(), language.English, status.WithTable(custom))
+
+	st, ok := grpcstatus.FromError(err)
+	if !ok {
+		t.Fatalf("FromError(%v) ok = false", err)
+	}
+	if st.Code() != grpccodes.Unavailable {
+		t.Errorf("Code() = %v, want %v", st.Code(), grpccodes.Unavailable)
+	}
+}
+
+func TestToConnectError(t *testing.T) {
+	t.Parallel()
+
+	err := status.ToConnectError(ErrNotFound, newMap(), language.English)
+
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) {
+		t.Fatalf("errors.As(%v, *connect.Error) = false", err)
+	}
+	if cerr.Code() != connect.CodeNotFound {
+		t.Errorf("Code() = %v, want %v", cerr.Code(), connect.CodeNotFound)
+	}
+	if cerr.Message() != "Not Found" {
+		t.Errorf("Message() = %q, want %q", cerr.Message(), "Not Found")
+	}
+}